@@ -3,21 +3,27 @@ package main
 import (
 	"bufio"
 	"encoding/json"
+	"flag"
 	"fmt"
-	"github.com/eiannone/keyboard"
-	"github.com/schollz/closestmatch"
-	"golang.org/x/term"
+	"github.com/ShauryaSwarup/CFScripts/internal/cache"
+	"github.com/ShauryaSwarup/CFScripts/internal/config"
+	tea "github.com/charmbracelet/bubbletea"
 	"io"
 	"net/http"
 	"os"
-	"os/exec"
-	"runtime"
 	"sort"
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 )
 
+// problemsCacheKey is fixed since the full problemset is cached once,
+// independent of handle or tag filter; the solved-problem cache key is
+// derived per handle set by solvedCacheKeyFor. Default TTLs live in
+// internal/config.
+const problemsCacheKey = "problems"
+
 var tags = []string{
 	"combine-tags-by-or", "2-sat", "binary search", "bitmasks", "brute force",
 	"chinese remainder theorem", "combinatorics", "constructive algorithms",
@@ -29,10 +35,18 @@ var tags = []string{
 	"trees", "two pointers",
 }
 
-// Function to fuzzy find tags
-func fuzzyFindTags(topics []string) map[string]string {
+// candidateTagsToShow caps how many fuzzy candidates are offered when a
+// topic is ambiguous.
+const candidateTagsToShow = 5
+
+// Function to fuzzy find tags. Exact (case-insensitive) matches are bound
+// immediately; otherwise FuzzyMatch ranks the known tags and the top match is
+// taken, unless the two best candidates tie on score, in which case the user
+// is asked to pick among the top candidateTagsToShow instead. reader must
+// be the same stdin reader the rest of main uses, so the disambiguation
+// prompt doesn't race a second buffered reader for input.
+func fuzzyFindTags(topics []string, reader *bufio.Reader) map[string]string {
 	matches := make(map[string]string)
-	tagMatcher := closestmatch.New(tags, []int{2}) // 2 is the maximum number of closest matches
 
 	for _, topic := range topics {
 		// First, check for an exact match
@@ -44,16 +58,51 @@ func fuzzyFindTags(topics []string) map[string]string {
 				break
 			}
 		}
+		if exactMatch {
+			continue
+		}
+
+		ranked := FuzzyMatch(topic, tags)
+		if len(ranked) == 0 {
+			// Nothing matched at all; keep the raw topic so the caller can
+			// surface the miss rather than silently dropping it.
+			matches[topic] = topic
+			continue
+		}
+
+		candidates := ranked
+		if len(candidates) > candidateTagsToShow {
+			candidates = candidates[:candidateTagsToShow]
+		}
 
-		// If no exact match, use fuzzy matching
-		if !exactMatch {
-			bestMatch := tagMatcher.Closest(topic)
-			matches[topic] = bestMatch
+		if len(candidates) == 1 || candidates[0].Score > candidates[1].Score {
+			matches[topic] = candidates[0].Candidate
+			continue
 		}
+
+		matches[topic] = pickAmbiguousTag(topic, candidates, reader)
 	}
 	return matches
 }
 
+// pickAmbiguousTag prompts the user to disambiguate between close fuzzy
+// candidates for topic, defaulting to the top-ranked one.
+func pickAmbiguousTag(topic string, candidates []Match, reader *bufio.Reader) string {
+	fmt.Printf("Ambiguous tag %q, which did you mean?\n", topic)
+	for i, candidate := range candidates {
+		fmt.Printf("  %d) %s (score %d)\n", i+1, candidate.Candidate, candidate.Score)
+	}
+	fmt.Print("Enter number (default 1): ")
+
+	line, _ := reader.ReadString('\n')
+	line = strings.TrimSpace(line)
+	choice, err := strconv.Atoi(line)
+	if err != nil || choice < 1 || choice > len(candidates) {
+		choice = 1
+	}
+	return candidates[choice-1].Candidate
+}
+
 // Struct to capture the individual problem
 type Problem struct {
 	ContestID   int      `json:"contestId"`
@@ -66,10 +115,14 @@ type Problem struct {
 	SolvedCount int      `json:"solvedCount"` // Solved count, merged from problemStatistics
 }
 
-// Function to fetch problems from Codeforces API
+// Function to fetch problems from Codeforces API. An empty tags slice fetches
+// the full problemset, which is what the cache stores so that retagging only
+// needs a local filter instead of another round trip.
 func fetchProblemsByTags(tags []string) ([]Problem, error) {
-	tagsString := strings.Join(tags, ";")
-	url := fmt.Sprintf("https://codeforces.com/api/problemset.problems?tags=%s", tagsString)
+	url := "https://codeforces.com/api/problemset.problems"
+	if len(tags) > 0 {
+		url += "?tags=" + strings.Join(tags, ";")
+	}
 
 	response, err := http.Get(url)
 	if err != nil {
@@ -169,10 +222,22 @@ func sortProblems(problems []Problem, order string) {
 	}
 }
 
-// Function to fetch solved problems for the user
-func fetchSolvedProblems() (map[string]struct{}, error) {
-	username := "shauncodes"
-	url := fmt.Sprintf("https://codeforces.com/api/user.status?handle=%s", username)
+// fetchSolvedProblemsForHandle fetches the solved-problem set for a single
+// handle. When apiKey/apiSecret are set the request is signed per the
+// Codeforces API authentication scheme, which raises the rate limit and
+// unlocks private contest data; otherwise it falls back to the plain
+// unauthenticated endpoint.
+func fetchSolvedProblemsForHandle(handle, apiKey, apiSecret string) (map[string]struct{}, error) {
+	var url string
+	if apiKey != "" && apiSecret != "" {
+		signed, err := signedCFRequestURL("user.status", map[string]string{"handle": handle}, apiKey, apiSecret)
+		if err != nil {
+			return nil, err
+		}
+		url = signed
+	} else {
+		url = fmt.Sprintf("https://codeforces.com/api/user.status?handle=%s", handle)
+	}
 
 	response, err := http.Get(url)
 	if err != nil {
@@ -181,7 +246,7 @@ func fetchSolvedProblems() (map[string]struct{}, error) {
 	defer response.Body.Close()
 
 	if response.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("failed to fetch user status: %s", response.Status)
+		return nil, fmt.Errorf("failed to fetch user status for %s: %s", handle, response.Status)
 	}
 
 	body, err := io.ReadAll(response.Body)
@@ -215,115 +280,238 @@ func fetchSolvedProblems() (map[string]struct{}, error) {
 	return solvedProblems, nil
 }
 
-func clearScreen() {
-	switch runtime.GOOS {
-	case "linux", "darwin":
-		cmd := exec.Command("clear")
-		cmd.Stdout = os.Stdout
-		cmd.Run()
-	case "windows":
-		cmd := exec.Command("cmd", "/c", "cls")
-		cmd.Stdout = os.Stdout
-		cmd.Run()
-	default:
-		// As a fallback, print 100 empty lines
-		for i := 0; i < 100; i++ {
-			fmt.Println()
+// fetchSolvedProblems unions the solved-problem sets across every configured
+// handle, which is what makes team/training setups with several accounts
+// useful.
+func fetchSolvedProblems(handles []string, apiKey, apiSecret string) (map[string]struct{}, error) {
+	solved := make(map[string]struct{})
+	for _, handle := range handles {
+		handleSolved, err := fetchSolvedProblemsForHandle(handle, apiKey, apiSecret)
+		if err != nil {
+			return nil, err
+		}
+		for key := range handleSolved {
+			solved[key] = struct{}{}
 		}
 	}
+	return solved, nil
 }
 
-// Helper function to truncate strings
-func truncateString(s string, maxLen int) string {
-	if len(s) > maxLen {
-		return s[:maxLen-3] + "..." // Keep the ellipsis
+// filterByTags keeps only problems carrying every tag in wantedTags
+// (case-insensitive), mirroring the AND semantics of the Codeforces
+// problemset.problems tags query so cached problems can be retagged locally.
+func filterByTags(problems []Problem, wantedTags []string) []Problem {
+	if len(wantedTags) == 0 {
+		return problems
+	}
+	filtered := []Problem{}
+	for _, problem := range problems {
+		hasAll := true
+		for _, want := range wantedTags {
+			found := false
+			for _, tag := range problem.Tags {
+				if strings.EqualFold(tag, want) {
+					found = true
+					break
+				}
+			}
+			if !found {
+				hasAll = false
+				break
+			}
+		}
+		if hasAll {
+			filtered = append(filtered, problem)
+		}
 	}
-	return s
+	return filtered
 }
 
-// Updated displayPage function
-func displayPage(problems []Problem, solvedProblems map[string]struct{}, page, pageSize int) {
-	// Get terminal size
-	terminalWidth, terminalHeight, err := term.GetSize(int(os.Stdout.Fd()))
-	if err != nil {
-		fmt.Println("Error getting terminal size:", err)
-		return
+// cachedProblems returns the full problemset, preferring the on-disk cache
+// unless refresh forces a re-fetch or offline forbids one, then applies
+// tagsToSearch locally.
+func cachedProblems(tagsToSearch []string, ttl time.Duration, refresh, offline bool) ([]Problem, error) {
+	var all []Problem
+	if !refresh {
+		if hit, err := cache.Load(problemsCacheKey, ttl, &all); err == nil && hit {
+			return filterByTags(all, tagsToSearch), nil
+		}
 	}
 
-	// Calculate how many rows can be displayed (leaving space for headers, etc.)
-	availableRows := terminalHeight - 4 // Adjust this based on header/footer space
-	if availableRows < 1 {
-		availableRows = 1
+	if offline {
+		return nil, fmt.Errorf("no fresh problem cache available and --offline was set")
 	}
 
-	// Calculate start and end indices for pagination
-	start := (page - 1) * pageSize
-	end := start + availableRows
-	if end > len(problems) {
-		end = len(problems)
+	all, err := fetchProblemsByTags(nil)
+	if err != nil {
+		return nil, err
 	}
+	if err := cache.Save(problemsCacheKey, all); err != nil {
+		fmt.Println("warning: failed to write problem cache:", err)
+	}
+	return filterByTags(all, tagsToSearch), nil
+}
 
-	clearScreen()
+// solvedCacheKeyFor derives a cache key from the configured handle set so
+// switching handles doesn't serve another user's stale solved-problem cache.
+func solvedCacheKeyFor(handles []string) string {
+	sorted := append([]string(nil), handles...)
+	sort.Strings(sorted)
+	return "solved-" + strings.Join(sorted, "_")
+}
 
-	// Determine column widths based on terminal width
-	const (
-		colContestIDWidth   = 12
-		colProblemWidth     = 10
-		colNameWidth        = 40 // Will be adjusted based on terminal width
-		colSolvedCountWidth = 12
-		colRatingWidth      = 12
-		colSolvedWidth      = 12
-	)
+// cachedSolvedProblems mirrors cachedProblems for the (much smaller, much
+// more volatile) solved-problem set, unioned across every configured handle.
+// Unlike cachedProblems, offline with no fresh cache returns an empty set
+// rather than an error.
+func cachedSolvedProblems(handles []string, apiKey, apiSecret string, ttl time.Duration, refresh, offline bool) (map[string]struct{}, error) {
+	key := solvedCacheKeyFor(handles)
+
+	var solved map[string]struct{}
+	if !refresh {
+		if hit, err := cache.Load(key, ttl, &solved); err == nil && hit {
+			return solved, nil
+		}
+	}
 
-	// Calculate available width for the name column
-	nameWidth := terminalWidth - (colContestIDWidth + colProblemWidth + colSolvedCountWidth + colRatingWidth + colSolvedWidth + 5) // Subtracting spaces
+	if offline {
+		return map[string]struct{}{}, nil
+	}
 
-	// Ensure the name width is within reasonable limits
-	if nameWidth < 10 {
-		nameWidth = 10
+	solved, err := fetchSolvedProblems(handles, apiKey, apiSecret)
+	if err != nil {
+		return nil, err
+	}
+	if err := cache.Save(key, solved); err != nil {
+		fmt.Println("warning: failed to write submissions cache:", err)
 	}
+	return solved, nil
+}
 
-	// Print header
-	fmt.Printf("\033[1;34m%-12s %-10s %-*s %-12s %-12s %-12s\033[0m\n", "Contest ID", "Problem", nameWidth, "Name", "Solved Count", "Rating", "Solved")
+// runSearch implements the `search` subcommand: it builds (or reuses) the
+// bleve index over the cached problemset and runs a single query string
+// against it, e.g. `+tags:dp +rating:>=1600 +rating:<=1900 name:tree
+// -tags:implementation`. This is the expressive counterpart to the
+// tag-only fast path in cachedProblems/filterByTags.
+func runSearch(args []string) {
+	fs := flag.NewFlagSet("search", flag.ExitOnError)
+	refresh := fs.Bool("refresh", false, "invalidate the on-disk cache and search index, then re-fetch")
+	offline := fs.Bool("offline", false, "skip network calls entirely and rely on the on-disk cache")
+	fs.Parse(args)
+
+	queryString := strings.Join(fs.Args(), " ")
+	if queryString == "" {
+		fmt.Println("usage: cfscripts search [--refresh] [--offline] <query>")
+		os.Exit(1)
+	}
 
-	// Print content rows
-	for _, problem := range problems[start:end] {
-		link := fmt.Sprintf("https://codeforces.com/contest/%d/problem/%s", problem.ContestID, problem.Index)
-		ratingColor := getColorByRating(problem.Rating)
-		problemKey := fmt.Sprintf("%d_%s", problem.ContestID, problem.Index)
+	cfg, err := config.Load(config.Config{})
+	if err != nil {
+		fmt.Println("Error loading config:", err)
+		os.Exit(1)
+	}
 
-		solvedMarker := "No"
-		solvedColor := "\033[1;31m" // Red for "No"
-		if _, exists := solvedProblems[problemKey]; exists {
-			solvedMarker = "Yes"
-			solvedColor = "\033[1;32m" // Green for "Yes"
+	if *refresh {
+		cache.Invalidate(problemsCacheKey)
+		cache.Invalidate(indexMetaKey)
+		if err := invalidateIndex(); err != nil {
+			fmt.Println("warning: failed to invalidate search index:", err)
 		}
+	}
+
+	problems, err := cachedProblems(nil, cfg.ProblemsTTL, *refresh, *offline)
+	if err != nil {
+		fmt.Println("Error fetching problems:", err)
+		os.Exit(1)
+	}
 
-		// Truncate the problem name if it's too long
-		truncatedName := truncateString(problem.Name, nameWidth)
+	idx, err := IndexProblems(problems, cfg.ProblemsTTL)
+	if err != nil {
+		fmt.Println("Error building search index:", err)
+		os.Exit(1)
+	}
+	defer idx.Close()
+
+	results, err := Search(idx, queryString)
+	if err != nil {
+		fmt.Println("Error running search:", err)
+		os.Exit(1)
+	}
 
-		fmt.Printf("\033[1;32m%-12d\033[0m \033[1;31m%-10s\033[0m \033]8;;%s\033\\%-*s\033]8;;\033\\ %-12d %s%-12d %s%s\033[0m\n",
-			problem.ContestID, problem.Index, link, nameWidth, truncatedName, problem.SolvedCount, ratingColor, problem.Rating, solvedColor, solvedMarker)
+	for _, p := range results {
+		fmt.Printf("%d%-3s %-50s rating:%-5d solved:%-6d tags:%s\n",
+			p.ContestID, p.Index, truncate(p.Name, 50), p.Rating, p.SolvedCount, strings.Join(p.Tags, ","))
 	}
-	fmt.Printf("\nPage %d of %d\n", page, (len(problems)+pageSize-1)/pageSize)
 }
 
-func printRow(columns []string, widths []int, isHeader bool) {
-	for i, col := range columns {
-		if isHeader {
-			fmt.Printf("│\033[1;34m %-*s\033[0m", widths[i]-1, col)
-		} else {
-			fmt.Printf("│ %-*s", widths[i]-1, col)
+// splitCommaList splits a comma-separated flag value into trimmed,
+// non-empty parts, or returns nil if s is empty.
+func splitCommaList(s string) []string {
+	if s == "" {
+		return nil
+	}
+	var out []string
+	for _, part := range strings.Split(s, ",") {
+		if trimmed := strings.TrimSpace(part); trimmed != "" {
+			out = append(out, trimmed)
 		}
 	}
-	fmt.Println("│")
+	return out
+}
+
+func truncate(s string, maxLen int) string {
+	if len(s) <= maxLen {
+		return s
+	}
+	return s[:maxLen-3] + "..."
 }
 
 func main() {
-	scanner := bufio.NewScanner(os.Stdin)
+	if len(os.Args) > 1 && os.Args[1] == "search" {
+		runSearch(os.Args[2:])
+		return
+	}
+
+	refresh := flag.Bool("refresh", false, "invalidate the on-disk cache and re-fetch from the Codeforces API")
+	offline := flag.Bool("offline", false, "skip network calls entirely and rely on the on-disk cache")
+	handleFlag := flag.String("handle", "", "comma-separated Codeforces handle(s), overrides config file and env")
+	apiKeyFlag := flag.String("api-key", "", "Codeforces API key, overrides config file and env")
+	apiSecretFlag := flag.String("api-secret", "", "Codeforces API secret, overrides config file and env")
+	minRatingFlag := flag.Int("min-rating", 0, "override the configured default minimum rating")
+	maxRatingFlag := flag.Int("max-rating", 0, "override the configured default maximum rating")
+	sortFlag := flag.String("sort", "", "override the configured default sort order (a/d)")
+	flag.Parse()
+
+	cfg, err := config.Load(config.Config{
+		Handles:   splitCommaList(*handleFlag),
+		APIKey:    *apiKeyFlag,
+		APISecret: *apiSecretFlag,
+		MinRating: *minRatingFlag,
+		MaxRating: *maxRatingFlag,
+		SortOrder: *sortFlag,
+	})
+	if err != nil {
+		fmt.Println("Error loading config:", err)
+		os.Exit(1)
+	}
+	if len(cfg.Handles) == 0 {
+		fmt.Println("No Codeforces handle configured. Set CFSCRIPTS_HANDLE, pass --handle, or add `handles: [...]` to your config.yaml.")
+		os.Exit(1)
+	}
+
+	if *refresh {
+		cache.Invalidate(problemsCacheKey)
+		cache.Invalidate(solvedCacheKeyFor(cfg.Handles))
+	}
+
+	// A single shared reader drives every stdin prompt in main (topics, tag
+	// disambiguation, ratings); mixing it with a Scanner or fmt.Scanln would
+	// let one reader's internal buffering swallow bytes meant for another.
+	reader := bufio.NewReader(os.Stdin)
+
 	fmt.Println("Enter the topics (comma-separated):")
-	scanner.Scan()
-	input := scanner.Text()
+	input, _ := reader.ReadString('\n')
+	input = strings.TrimSpace(input)
 
 	words := strings.Split(input, ",")
 	var topics []string
@@ -335,7 +523,7 @@ func main() {
 		}
 	}
 
-	matches := fuzzyFindTags(topics)
+	matches := fuzzyFindTags(topics, reader)
 	fmt.Println("Fuzzy Matches:")
 	for topic, match := range matches {
 		fmt.Printf("%s -> %s\n", topic, match)
@@ -356,20 +544,33 @@ func main() {
 
 	go func() {
 		defer wg.Done()
-		problems, problemsErr = fetchProblemsByTags(tagsToSearch)
+		problems, problemsErr = cachedProblems(tagsToSearch, cfg.ProblemsTTL, *refresh, *offline)
 	}()
 
 	go func() {
 		defer wg.Done()
-		solvedProblems, solvedErr = fetchSolvedProblems()
+		solvedProblems, solvedErr = cachedSolvedProblems(cfg.Handles, cfg.APIKey, cfg.APISecret, cfg.SubmissionsTTL, *refresh, *offline)
 	}()
 
-	// Get user input while fetching is in progress
-	var minRating, maxRating int
-	var order string
-
-	fmt.Println("Enter min and max rating | Sort Order (a/d):")
-	fmt.Scanf("%d %d %s", &minRating, &maxRating, &order)
+	// Get user input while fetching is in progress; blank input falls back
+	// to the configured rating range and sort order.
+	minRating, maxRating, order := cfg.MinRating, cfg.MaxRating, cfg.SortOrder
+	fmt.Printf("Enter min and max rating | Sort Order (a/d) [default: %d %d %s]:\n", cfg.MinRating, cfg.MaxRating, cfg.SortOrder)
+	ratingLine, _ := reader.ReadString('\n')
+	fields := strings.Fields(ratingLine)
+	if len(fields) > 0 {
+		if v, err := strconv.Atoi(fields[0]); err == nil {
+			minRating = v
+		}
+	}
+	if len(fields) > 1 {
+		if v, err := strconv.Atoi(fields[1]); err == nil {
+			maxRating = v
+		}
+	}
+	if len(fields) > 2 {
+		order = fields[2]
+	}
 
 	// Wait for fetching to complete
 	wg.Wait()
@@ -384,57 +585,12 @@ func main() {
 		return
 	}
 
-	// Filter and sort problems
-	filteredProblems := filterProblemsByRating(problems, minRating, maxRating)
-	sortProblems(filteredProblems, order)
-
-	pageSize := 20 // Number of problems to display per page
-	currentPage := 1
-	if err := keyboard.Open(); err != nil {
-		panic(err)
-	}
-	defer keyboard.Close()
-
-	for {
-		displayPage(filteredProblems, solvedProblems, currentPage, pageSize)
-
-		fmt.Println("\nPress 'n' for next page, 'p' for previous page, 'j' to jump to a page, or 'q' to quit:")
-
-		char, key, err := keyboard.GetKey()
-		if err != nil {
-			panic(err)
-		}
-
-		switch char {
-		case 'n':
-			if currentPage*pageSize < len(filteredProblems) {
-				currentPage++
-			}
-		case 'p':
-			if currentPage > 1 {
-				currentPage--
-			}
-		case 'q':
-			return
-		case 'j':
-			keyboard.Close()
-			fmt.Print("Enter page number: ")
-			var pageInput string
-			fmt.Scanln(&pageInput)
-			pageNum, err := strconv.Atoi(pageInput)
-			if err == nil && pageNum >= 1 && pageNum <= (len(filteredProblems)+pageSize-1)/pageSize {
-				currentPage = pageNum
-			} else {
-				fmt.Println("Invalid page number. Press any key to continue.")
-				keyboard.GetKey()
-			}
-			if err := keyboard.Open(); err != nil {
-				panic(err)
-			}
-		}
-
-		if key == keyboard.KeyCtrlC {
-			break
-		}
+	// Hand off to the interactive Bubble Tea view; rating filtering/sorting
+	// continue to run through filterProblemsByRating/sortProblems as the
+	// user refines the query or toggles sort order.
+	m := newModel(problems, solvedProblems, minRating, maxRating, order)
+	if _, err := tea.NewProgram(m, tea.WithAltScreen()).Run(); err != nil {
+		fmt.Println("Error running TUI:", err)
+		os.Exit(1)
 	}
 }
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"sort"
+	"strings"
+)
+
+// Match is a single scored result from FuzzyMatch.
+type Match struct {
+	Candidate string
+	Score     int
+	// Span is the length of the matched substring within Candidate (from the
+	// first matched character to the last), used as a tiebreaker.
+	Span int
+}
+
+const (
+	scorePerMatch      = 16
+	scoreConsecutive   = 8
+	scoreWordBoundary  = 8
+	penaltyPerGapChar  = 2
+	minFuzzyMatchScore = 0
+)
+
+// FuzzyMatch ranks candidates against query fzf-style: it finds the
+// leftmost matching subsequence of query inside each candidate
+// (case-insensitive), rewards consecutive characters and matches that start
+// at a word boundary (after '-' or a space, or at the very start), and
+// penalizes gaps between matched characters. Candidates that don't contain
+// query as a subsequence are dropped. Results are ranked by score
+// descending, then by the length of the matched substring ascending (a
+// tighter match wins, as in fzf), then by candidate length ascending.
+func FuzzyMatch(query string, candidates []string) []Match {
+	if query == "" {
+		matches := make([]Match, len(candidates))
+		for i, c := range candidates {
+			matches[i] = Match{Candidate: c}
+		}
+		return matches
+	}
+
+	q := strings.ToLower(query)
+	matches := make([]Match, 0, len(candidates))
+	for _, candidate := range candidates {
+		if score, span, ok := scoreSubsequence(q, strings.ToLower(candidate)); ok {
+			matches = append(matches, Match{Candidate: candidate, Score: score, Span: span})
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].Score != matches[j].Score {
+			return matches[i].Score > matches[j].Score
+		}
+		if matches[i].Span != matches[j].Span {
+			return matches[i].Span < matches[j].Span
+		}
+		return len(matches[i].Candidate) < len(matches[j].Candidate)
+	})
+
+	return matches
+}
+
+// scoreSubsequence finds the leftmost occurrence of q as a subsequence of t
+// and scores it. It returns ok=false if q is not a subsequence of t at all.
+func scoreSubsequence(q, t string) (score, span int, ok bool) {
+	positions := make([]int, 0, len(q))
+	searchFrom := 0
+	for _, ch := range q {
+		idx := strings.IndexRune(t[searchFrom:], ch)
+		if idx < 0 {
+			return 0, 0, false
+		}
+		pos := searchFrom + idx
+		positions = append(positions, pos)
+		searchFrom = pos + 1
+	}
+
+	prev := -2
+	for _, pos := range positions {
+		score += scorePerMatch
+		if pos == prev+1 {
+			score += scoreConsecutive
+		}
+		if pos == 0 || t[pos-1] == '-' || t[pos-1] == ' ' {
+			score += scoreWordBoundary
+		}
+		if prev >= 0 && pos > prev+1 {
+			score -= (pos - prev - 1) * penaltyPerGapChar
+		}
+		prev = pos
+	}
+
+	span = positions[len(positions)-1] - positions[0] + 1
+	return score, span, true
+}
@@ -0,0 +1,83 @@
+package main
+
+import "testing"
+
+func TestFuzzyMatchPicksExpectedTopCandidate(t *testing.T) {
+	cases := []struct {
+		name       string
+		query      string
+		candidates []string
+		want       string
+	}{
+		{
+			name:       "exact short tag wins over longer candidates",
+			query:      "dp",
+			candidates: []string{"dp", "data structures", "divide and conquer"},
+			want:       "dp",
+		},
+		{
+			name:       "prefix match beats a match starting mid-string",
+			query:      "geo",
+			candidates: []string{"geometry", "graph matchings"},
+			want:       "geometry",
+		},
+		{
+			name:       "word-boundary match after a hyphen beats a mid-word match",
+			query:      "sat",
+			candidates: []string{"2-sat", "constructive algorithms"},
+			want:       "2-sat",
+		},
+		{
+			name:       "tighter consecutive match beats a scattered one",
+			query:      "ab",
+			candidates: []string{"xaxbx", "abxxxx"},
+			want:       "abxxxx",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := FuzzyMatch(tc.query, tc.candidates)
+			if len(got) == 0 {
+				t.Fatalf("FuzzyMatch(%q, %v) returned no matches", tc.query, tc.candidates)
+			}
+			if got[0].Candidate != tc.want {
+				t.Errorf("FuzzyMatch(%q, %v)[0] = %q, want %q", tc.query, tc.candidates, got[0].Candidate, tc.want)
+			}
+		})
+	}
+}
+
+func TestFuzzyMatchDropsNonSubsequences(t *testing.T) {
+	got := FuzzyMatch("xyz", []string{"dp", "geometry", "trees"})
+	if len(got) != 0 {
+		t.Errorf("FuzzyMatch(%q, ...) = %v, want no matches", "xyz", got)
+	}
+}
+
+func TestFuzzyMatchTiebreaksBySpanThenCandidateLength(t *testing.T) {
+	// "ab" and "abxxxx" both match "ab" as a tight, consecutive subsequence
+	// starting at index 0 (a word boundary), so they tie on score and on
+	// span (2); "xxxxab" gets no word-boundary bonus and ranks last. The
+	// shorter candidate should win the final length tiebreak.
+	got := FuzzyMatch("ab", []string{"xxxxab", "abxxxx", "ab"})
+	if len(got) != 3 {
+		t.Fatalf("FuzzyMatch(%q, ...) = %v, want 3 matches", "ab", got)
+	}
+	for i := 0; i < len(got)-1; i++ {
+		if got[i].Score < got[i+1].Score {
+			t.Fatalf("matches not sorted by descending score: %v", got)
+		}
+	}
+	if got[0].Candidate != "ab" {
+		t.Errorf("FuzzyMatch(%q, ...)[0] = %q, want %q (shortest exact span)", "ab", got[0].Candidate, "ab")
+	}
+}
+
+func TestFuzzyMatchEmptyQueryReturnsAllUnscored(t *testing.T) {
+	candidates := []string{"dp", "geometry"}
+	got := FuzzyMatch("", candidates)
+	if len(got) != len(candidates) {
+		t.Fatalf("FuzzyMatch(\"\", %v) = %v, want one Match per candidate", candidates, got)
+	}
+}
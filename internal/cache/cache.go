@@ -0,0 +1,102 @@
+// Package cache provides a small on-disk JSON cache with TTL-based
+// invalidation, used to avoid re-hitting the Codeforces API on every run.
+package cache
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// entry wraps a cached value with the time it was written, so Load can
+// decide whether it is still within its TTL.
+type entry struct {
+	CachedAt time.Time       `json:"cachedAt"`
+	Data     json.RawMessage `json:"data"`
+}
+
+// Dir returns the cache directory, preferring $XDG_CACHE_HOME/cfscripts and
+// falling back to ~/.cache/cfscripts.
+func Dir() (string, error) {
+	if base := os.Getenv("XDG_CACHE_HOME"); base != "" {
+		return filepath.Join(base, "cfscripts"), nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".cache", "cfscripts"), nil
+}
+
+func path(key string) (string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, key+".json"), nil
+}
+
+// Load decodes the cached value for key into out, reporting whether a value
+// was found and is younger than ttl. A miss (no file, expired, or corrupt
+// entry) returns (false, nil) so callers can fall back to fetching.
+func Load(key string, ttl time.Duration, out interface{}) (bool, error) {
+	p, err := path(key)
+	if err != nil {
+		return false, err
+	}
+	raw, err := os.ReadFile(p)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	var e entry
+	if err := json.Unmarshal(raw, &e); err != nil {
+		return false, nil
+	}
+	if time.Since(e.CachedAt) > ttl {
+		return false, nil
+	}
+	if err := json.Unmarshal(e.Data, out); err != nil {
+		return false, nil
+	}
+	return true, nil
+}
+
+// Save persists v under key, stamped with the current time.
+func Save(key string, v interface{}) error {
+	dir, err := Dir()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	raw, err := json.Marshal(entry{CachedAt: time.Now(), Data: data})
+	if err != nil {
+		return err
+	}
+	p, err := path(key)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(p, raw, 0o644)
+}
+
+// Invalidate removes the cached entry for key, used to implement --refresh.
+func Invalidate(key string) error {
+	p, err := path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(p); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+package cache
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+type payload struct {
+	Value string `json:"value"`
+}
+
+func TestLoadFreshHit(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+
+	if err := Save("key", payload{Value: "hello"}); err != nil {
+		t.Fatalf("Save() error: %v", err)
+	}
+
+	var got payload
+	fresh, err := Load("key", time.Hour, &got)
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if !fresh {
+		t.Fatal("Load() = fresh false, want true for a just-written entry")
+	}
+	if got.Value != "hello" {
+		t.Errorf("Load() out = %+v, want Value %q", got, "hello")
+	}
+}
+
+func TestLoadExpiredTTLMiss(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+
+	if err := Save("key", payload{Value: "hello"}); err != nil {
+		t.Fatalf("Save() error: %v", err)
+	}
+
+	var got payload
+	fresh, err := Load("key", -time.Second, &got)
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if fresh {
+		t.Fatal("Load() = fresh true, want false for an entry older than ttl")
+	}
+}
+
+func TestLoadCorruptJSONMiss(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("XDG_CACHE_HOME", dir)
+
+	if err := os.MkdirAll(filepath.Join(dir, "cfscripts"), 0o755); err != nil {
+		t.Fatalf("MkdirAll() error: %v", err)
+	}
+	p := filepath.Join(dir, "cfscripts", "key.json")
+	if err := os.WriteFile(p, []byte("not json"), 0o644); err != nil {
+		t.Fatalf("WriteFile() error: %v", err)
+	}
+
+	var got payload
+	fresh, err := Load("key", time.Hour, &got)
+	if err != nil {
+		t.Fatalf("Load() error: %v, want no error on corrupt entry (treat as a miss)", err)
+	}
+	if fresh {
+		t.Fatal("Load() = fresh true, want false for a corrupt entry")
+	}
+}
+
+func TestLoadMissingKeyMiss(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+
+	var got payload
+	fresh, err := Load("missing", time.Hour, &got)
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if fresh {
+		t.Fatal("Load() = fresh true, want false when no entry was ever saved")
+	}
+}
+
+func TestInvalidate(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+
+	if err := Save("key", payload{Value: "hello"}); err != nil {
+		t.Fatalf("Save() error: %v", err)
+	}
+	if err := Invalidate("key"); err != nil {
+		t.Fatalf("Invalidate() error: %v", err)
+	}
+
+	var got payload
+	fresh, err := Load("key", time.Hour, &got)
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if fresh {
+		t.Fatal("Load() = fresh true after Invalidate(), want false")
+	}
+}
+
+func TestInvalidateMissingKeyIsNotAnError(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+
+	if err := Invalidate("never-saved"); err != nil {
+		t.Fatalf("Invalidate() on a missing key error: %v, want nil", err)
+	}
+}
@@ -0,0 +1,126 @@
+// Package config loads cfscripts' layered configuration: CLI flags take
+// priority over environment variables, which take priority over the
+// on-disk config file, which takes priority over built-in defaults.
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Config holds every setting a user might want to override: which
+// Codeforces handle(s) to union solved problems across, optional API
+// credentials for authenticated (higher rate-limit) calls, the rating
+// presets and sort order the interactive prompt defaults to, and the cache
+// TTLs.
+type Config struct {
+	Handles   []string `yaml:"handles"`
+	APIKey    string   `yaml:"apiKey"`
+	APISecret string   `yaml:"apiSecret"`
+
+	MinRating int    `yaml:"minRating"`
+	MaxRating int    `yaml:"maxRating"`
+	SortOrder string `yaml:"sortOrder"`
+
+	ProblemsTTL    time.Duration `yaml:"problemsTTL"`
+	SubmissionsTTL time.Duration `yaml:"submissionsTTL"`
+}
+
+func defaults() Config {
+	return Config{
+		MinRating:      800,
+		MaxRating:      3500,
+		SortOrder:      "a",
+		ProblemsTTL:    24 * time.Hour,
+		SubmissionsTTL: 1 * time.Hour,
+	}
+}
+
+// Path returns the config file location: $XDG_CONFIG_HOME/cfscripts/config.yaml,
+// falling back to ~/.config/cfscripts/config.yaml.
+func Path() (string, error) {
+	if base := os.Getenv("XDG_CONFIG_HOME"); base != "" {
+		return filepath.Join(base, "cfscripts", "config.yaml"), nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".config", "cfscripts", "config.yaml"), nil
+}
+
+func fromFile() (Config, error) {
+	cfg := defaults()
+	path, err := Path()
+	if err != nil {
+		return cfg, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return cfg, nil
+		}
+		return cfg, err
+	}
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return cfg, err
+	}
+	return cfg, nil
+}
+
+func applyEnv(cfg *Config) {
+	if v := os.Getenv("CFSCRIPTS_HANDLE"); v != "" {
+		cfg.Handles = []string{v}
+	}
+	if v := os.Getenv("CFSCRIPTS_API_KEY"); v != "" {
+		cfg.APIKey = v
+	}
+	if v := os.Getenv("CFSCRIPTS_API_SECRET"); v != "" {
+		cfg.APISecret = v
+	}
+}
+
+// applyOverrides layers any non-zero fields of o (typically parsed CLI
+// flags) on top of cfg, which has already had the config file and
+// environment applied.
+func applyOverrides(cfg *Config, o Config) {
+	if len(o.Handles) > 0 {
+		cfg.Handles = o.Handles
+	}
+	if o.APIKey != "" {
+		cfg.APIKey = o.APIKey
+	}
+	if o.APISecret != "" {
+		cfg.APISecret = o.APISecret
+	}
+	if o.MinRating != 0 {
+		cfg.MinRating = o.MinRating
+	}
+	if o.MaxRating != 0 {
+		cfg.MaxRating = o.MaxRating
+	}
+	if o.SortOrder != "" {
+		cfg.SortOrder = o.SortOrder
+	}
+	if o.ProblemsTTL != 0 {
+		cfg.ProblemsTTL = o.ProblemsTTL
+	}
+	if o.SubmissionsTTL != 0 {
+		cfg.SubmissionsTTL = o.SubmissionsTTL
+	}
+}
+
+// Load builds the effective Config from the file, the environment, and
+// finally overrides (typically parsed CLI flags), in increasing priority.
+func Load(overrides Config) (Config, error) {
+	cfg, err := fromFile()
+	if err != nil {
+		return cfg, err
+	}
+	applyEnv(&cfg)
+	applyOverrides(&cfg, overrides)
+	return cfg, nil
+}
@@ -0,0 +1,93 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestLoadDefaultsWhenNothingIsSet(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	cfg, err := Load(Config{})
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	want := defaults()
+	if !reflect.DeepEqual(cfg, want) {
+		t.Errorf("Load() = %+v, want defaults %+v", cfg, want)
+	}
+}
+
+func TestLoadFileIsOverriddenByEnvAndFlags(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+
+	if err := os.MkdirAll(filepath.Join(dir, "cfscripts"), 0o755); err != nil {
+		t.Fatalf("MkdirAll() error: %v", err)
+	}
+	yaml := "handles: [\"file-handle\"]\napiKey: file-key\nminRating: 1200\nsortOrder: d\n"
+	if err := os.WriteFile(filepath.Join(dir, "cfscripts", "config.yaml"), []byte(yaml), 0o644); err != nil {
+		t.Fatalf("WriteFile() error: %v", err)
+	}
+
+	// File alone should win over defaults.
+	cfg, err := Load(Config{})
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if len(cfg.Handles) != 1 || cfg.Handles[0] != "file-handle" {
+		t.Errorf("Handles = %v, want [file-handle] from the config file", cfg.Handles)
+	}
+	if cfg.MinRating != 1200 {
+		t.Errorf("MinRating = %d, want 1200 from the config file", cfg.MinRating)
+	}
+	if cfg.MaxRating != defaults().MaxRating {
+		t.Errorf("MaxRating = %d, want the default %d to survive (file didn't set it)", cfg.MaxRating, defaults().MaxRating)
+	}
+
+	// Env should win over the file.
+	t.Setenv("CFSCRIPTS_HANDLE", "env-handle")
+	cfg, err = Load(Config{})
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if len(cfg.Handles) != 1 || cfg.Handles[0] != "env-handle" {
+		t.Errorf("Handles = %v, want [env-handle] from the environment", cfg.Handles)
+	}
+
+	// CLI overrides should win over both.
+	cfg, err = Load(Config{Handles: []string{"flag-handle"}, SortOrder: "a"})
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if len(cfg.Handles) != 1 || cfg.Handles[0] != "flag-handle" {
+		t.Errorf("Handles = %v, want [flag-handle] from overrides", cfg.Handles)
+	}
+	if cfg.SortOrder != "a" {
+		t.Errorf("SortOrder = %q, want %q from overrides", cfg.SortOrder, "a")
+	}
+}
+
+func TestLoadMissingFileIsNotAnError(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	cfg, err := Load(Config{MinRating: 900})
+	if err != nil {
+		t.Fatalf("Load() error: %v, want nil when no config file exists", err)
+	}
+	if cfg.MinRating != 900 {
+		t.Errorf("MinRating = %d, want override 900 to still apply", cfg.MinRating)
+	}
+}
+
+func TestApplyOverridesLeavesZeroValuesAlone(t *testing.T) {
+	cfg := Config{MinRating: 800, MaxRating: 3500, SortOrder: "a", ProblemsTTL: 24 * time.Hour}
+	applyOverrides(&cfg, Config{})
+	want := Config{MinRating: 800, MaxRating: 3500, SortOrder: "a", ProblemsTTL: 24 * time.Hour}
+	if !reflect.DeepEqual(cfg, want) {
+		t.Errorf("applyOverrides with a zero-value override = %+v, want unchanged %+v", cfg, want)
+	}
+}
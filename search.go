@@ -0,0 +1,170 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/ShauryaSwarup/CFScripts/internal/cache"
+	"github.com/blevesearch/bleve/v2"
+	"github.com/blevesearch/bleve/v2/analysis/analyzer/keyword"
+	"github.com/blevesearch/bleve/v2/mapping"
+)
+
+// indexFileName is the bleve index directory, persisted alongside the JSON
+// problem cache so a search doesn't need to re-fetch or re-index.
+const indexFileName = "problems.bleve"
+
+// indexMetaKey tracks when the index was last built and over how many
+// problems, so IndexProblems can tell a stale index from a fresh one
+// instead of trusting "the directory exists" as "up to date".
+const indexMetaKey = "search-index-meta"
+
+type indexMeta struct {
+	Count int `json:"count"`
+}
+
+func indexPath() (string, error) {
+	dir, err := cache.Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, indexFileName), nil
+}
+
+// newProblemIndexMapping maps Problem fields the way the search subcommand
+// queries them: tags are indexed as exact keywords (so "tags:dp" matches
+// the literal tag rather than a tokenized word), while name stays full-text.
+func newProblemIndexMapping() mapping.IndexMapping {
+	nameField := bleve.NewTextFieldMapping()
+
+	tagField := bleve.NewTextFieldMapping()
+	tagField.Analyzer = keyword.Name
+
+	ratingField := bleve.NewNumericFieldMapping()
+	solvedCountField := bleve.NewNumericFieldMapping()
+	contestIDField := bleve.NewNumericFieldMapping()
+
+	problem := bleve.NewDocumentMapping()
+	problem.AddFieldMappingsAt("name", nameField)
+	problem.AddFieldMappingsAt("tags", tagField)
+	problem.AddFieldMappingsAt("rating", ratingField)
+	problem.AddFieldMappingsAt("solvedCount", solvedCountField)
+	problem.AddFieldMappingsAt("contestId", contestIDField)
+
+	im := bleve.NewIndexMapping()
+	im.DefaultMapping = problem
+	return im
+}
+
+func problemDocID(p Problem) string {
+	return problemKey(p)
+}
+
+// IndexProblems returns a bleve index over problems, persisted under the
+// cache dir. An existing on-disk index is reused only if it's within ttl
+// (the same TTL governing the JSON problem cache) and was built over the
+// same number of problems; otherwise it's rebuilt from scratch, so the
+// search path stays in step with cachedProblems' own refresh/--refresh
+// behavior instead of serving a stale index forever.
+func IndexProblems(problems []Problem, ttl time.Duration) (bleve.Index, error) {
+	path, err := indexPath()
+	if err != nil {
+		return nil, err
+	}
+
+	var meta indexMeta
+	if fresh, err := cache.Load(indexMetaKey, ttl, &meta); err == nil && fresh && meta.Count == len(problems) {
+		if idx, err := bleve.Open(path); err == nil {
+			return idx, nil
+		}
+	}
+
+	if err := invalidateIndex(); err != nil {
+		return nil, err
+	}
+
+	idx, err := bleve.New(path, newProblemIndexMapping())
+	if err != nil {
+		return nil, err
+	}
+
+	batch := idx.NewBatch()
+	for _, p := range problems {
+		if err := batch.Index(problemDocID(p), p); err != nil {
+			return nil, err
+		}
+	}
+	if err := idx.Batch(batch); err != nil {
+		return nil, err
+	}
+
+	if err := cache.Save(indexMetaKey, indexMeta{Count: len(problems)}); err != nil {
+		return nil, err
+	}
+
+	return idx, nil
+}
+
+// invalidateIndex removes the persisted search index so the next
+// IndexProblems call rebuilds it from scratch.
+func invalidateIndex() error {
+	path, err := indexPath()
+	if err != nil {
+		return err
+	}
+	if err := os.RemoveAll(path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// Search runs an fzf-unrelated, full Lucene-style query string against idx
+// (e.g. `+tags:dp +rating:>=1600 +rating:<=1900 name:tree -tags:implementation`)
+// and reconstructs the matching Problems from the index's stored fields.
+func Search(idx bleve.Index, queryString string) ([]Problem, error) {
+	q := bleve.NewQueryStringQuery(queryString)
+	req := bleve.NewSearchRequestOptions(q, 200, 0, false)
+	req.Fields = []string{"contestId", "index", "name", "tags", "rating", "solvedCount"}
+
+	result, err := idx.Search(req)
+	if err != nil {
+		return nil, err
+	}
+
+	problems := make([]Problem, 0, len(result.Hits))
+	for _, hit := range result.Hits {
+		problems = append(problems, problemFromFields(hit.Fields))
+	}
+	return problems, nil
+}
+
+func problemFromFields(fields map[string]interface{}) Problem {
+	var p Problem
+	if v, ok := fields["contestId"].(float64); ok {
+		p.ContestID = int(v)
+	}
+	if v, ok := fields["index"].(string); ok {
+		p.Index = v
+	}
+	if v, ok := fields["name"].(string); ok {
+		p.Name = v
+	}
+	if v, ok := fields["rating"].(float64); ok {
+		p.Rating = int(v)
+	}
+	if v, ok := fields["solvedCount"].(float64); ok {
+		p.SolvedCount = int(v)
+	}
+	switch tags := fields["tags"].(type) {
+	case []interface{}:
+		for _, t := range tags {
+			if s, ok := t.(string); ok {
+				p.Tags = append(p.Tags, s)
+			}
+		}
+	case string:
+		p.Tags = []string{tags}
+	}
+	return p
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"sort"
+	"testing"
+	"time"
+)
+
+func sampleProblems() []Problem {
+	return []Problem{
+		{ContestID: 1, Index: "A", Name: "Watermelon", Rating: 800, Tags: []string{"math", "brute force"}, SolvedCount: 100},
+		{ContestID: 1, Index: "B", Name: "Theatre Square", Rating: 1000, Tags: []string{"math"}, SolvedCount: 50},
+		{ContestID: 2, Index: "A", Name: "Spanning Tree", Rating: 1900, Tags: []string{"dp", "trees"}, SolvedCount: 10},
+	}
+}
+
+func problemNames(problems []Problem) []string {
+	names := make([]string, 0, len(problems))
+	for _, p := range problems {
+		names = append(names, p.Name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func TestIndexProblemsAndSearch(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+
+	problems := sampleProblems()
+	idx, err := IndexProblems(problems, time.Hour)
+	if err != nil {
+		t.Fatalf("IndexProblems() error: %v", err)
+	}
+	defer idx.Close()
+
+	got, err := Search(idx, "tags:math")
+	if err != nil {
+		t.Fatalf("Search() error: %v", err)
+	}
+	if want := []string{"Theatre Square", "Watermelon"}; !equalStrings(problemNames(got), want) {
+		t.Errorf("Search(tags:math) = %v, want %v", problemNames(got), want)
+	}
+}
+
+func TestIndexProblemsRebuildsWhenProblemCountChanges(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+
+	problems := sampleProblems()
+	idx, err := IndexProblems(problems, time.Hour)
+	if err != nil {
+		t.Fatalf("IndexProblems() error: %v", err)
+	}
+	idx.Close()
+
+	grown := append(sampleProblems(), Problem{ContestID: 3, Index: "C", Name: "New Problem", Rating: 1500, Tags: []string{"greedy"}})
+	idx, err = IndexProblems(grown, time.Hour)
+	if err != nil {
+		t.Fatalf("IndexProblems() on a grown problem set error: %v", err)
+	}
+	defer idx.Close()
+
+	got, err := Search(idx, "tags:greedy")
+	if err != nil {
+		t.Fatalf("Search() error: %v", err)
+	}
+	if len(got) != 1 || got[0].Name != "New Problem" {
+		t.Errorf("Search(tags:greedy) = %v, want just New Problem (stale index wasn't rebuilt)", got)
+	}
+}
+
+func TestIndexProblemsRebuildsWhenTTLExpired(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+
+	problems := sampleProblems()
+	idx, err := IndexProblems(problems, time.Hour)
+	if err != nil {
+		t.Fatalf("IndexProblems() error: %v", err)
+	}
+	idx.Close()
+
+	idx, err = IndexProblems(problems, -time.Second)
+	if err != nil {
+		t.Fatalf("IndexProblems() with an expired ttl error: %v", err)
+	}
+	defer idx.Close()
+
+	got, err := Search(idx, "tags:math")
+	if err != nil {
+		t.Fatalf("Search() error: %v", err)
+	}
+	if len(got) != 2 {
+		t.Errorf("Search(tags:math) after a forced rebuild = %v, want 2 hits", got)
+	}
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}